@@ -16,6 +16,22 @@ type Application struct {
 
 	// application-dependent data
 	Data []byte
+
+	// Payload is the typed value decoded from Data by a codec registered
+	// via RegisterApplicationCodec/RegisterApplicationCodecForName for
+	// this packet's Name and SubType. It is nil when no codec matched, in
+	// which case Data holds the raw bytes. When set before calling
+	// Marshal or MarshalTo, the matching codec encodes Payload into the
+	// returned wire bytes; since both have value receivers, this does not
+	// write back into the caller's Data field. MarshalSize and Header
+	// have pointer receivers and DO resolve a pending Payload into Data
+	// as a side effect, so that a buffer sized from MarshalSize() is
+	// always big enough for the MarshalTo() that follows it.
+	Payload any
+
+	// Raw is set by UnmarshalNoCopy to the buffer Data aliases into. It is
+	// nil after Unmarshal, which copies Data out of the input buffer.
+	Raw []byte
 }
 
 var _ Packet = (*Application)(nil) // assert is a Packet
@@ -27,8 +43,64 @@ const (
 	appDataOffset = appNameOffset + appNameLength
 )
 
-// Marshal encodes the Application packet in binary
+// Marshal encodes the Application packet in binary. If Payload is set, it
+// is encoded via the codec registered for Name/SubType; Marshal has a
+// value receiver, so this only affects the returned bytes and never
+// mutates the caller's Data field.
 func (app Application) Marshal() ([]byte, error) {
+	if err := app.encodePayload(); err != nil {
+		return nil, err
+	}
+
+	rawPacket := make([]byte, app.sizeFromData())
+	if _, err := app.marshalTo(rawPacket); err != nil {
+		return nil, err
+	}
+
+	return rawPacket, nil
+}
+
+// MarshalTo encodes the Application packet into buf, which must be at
+// least MarshalSize() bytes long, and returns the number of bytes
+// written. Unlike Marshal, it does not allocate, so callers that reuse
+// buf across packets (e.g. an SFU forwarding RTCP) avoid churning the
+// allocator. As with Marshal, a set Payload is encoded into the written
+// bytes only; the caller's Data field is left untouched.
+func (app Application) MarshalTo(buf []byte) (int, error) {
+	if err := app.encodePayload(); err != nil {
+		return 0, err
+	}
+
+	return app.marshalTo(buf)
+}
+
+// encodePayload re-encodes Payload into Data via the registered codec, if
+// Payload is set.
+func (app *Application) encodePayload() error {
+	if app.Payload == nil {
+		return nil
+	}
+
+	codec := applicationCodecFor(app.Name, app.SubType)
+	if codec == nil {
+		return errNoApplicationCodecForPayload
+	}
+	if err := codec.Validate(app.Payload); err != nil {
+		return err
+	}
+
+	data, err := codec.Marshal(app.Payload)
+	if err != nil {
+		return err
+	}
+	app.Data = data
+
+	return nil
+}
+
+// marshalTo writes the header, SSRC, Name and Data into buf. It assumes
+// Payload, if any, has already been encoded into Data.
+func (app Application) marshalTo(buf []byte) (int, error) {
 	/*
 	 *  0                   1                   2                   3
 	 *  0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
@@ -43,28 +115,70 @@ func (app Application) Marshal() ([]byte, error) {
 	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
 	 */
 
-	rawPacket := make([]byte, app.MarshalSize())
-	packetBody := rawPacket[headerLength:]
+	size := app.sizeFromData()
+	if len(buf) < size {
+		return 0, errBufferTooSmall
+	}
 
+	packetBody := buf[headerLength:size]
 	binary.BigEndian.PutUint32(packetBody, app.SSRC)
 	copy(packetBody[appNameOffset:], app.Name[:])
 	copy(packetBody[appDataOffset:], app.Data)
 
-	hData, err := app.Header().Marshal()
+	hData, err := app.headerFromData().Marshal()
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	copy(rawPacket, hData)
+	copy(buf, hData)
 
 	if getPadding(app.packetLen()) != 0 {
-		rawPacket[len(rawPacket)-1] = uint8(app.MarshalSize() - app.packetLen())
+		for i := app.packetLen(); i < size-1; i++ {
+			buf[i] = 0
+		}
+		buf[size-1] = uint8(size - app.packetLen())
 	}
 
-	return rawPacket, nil
+	return size, nil
 }
 
-// Unmarshal decodes the Application packet from binary
+// Unmarshal decodes the Application packet from binary, copying Data out
+// of rawPacket so the caller may reuse it immediately.
 func (app *Application) Unmarshal(rawPacket []byte) error {
+	dataBody, err := app.unmarshalHeader(rawPacket)
+	if err != nil {
+		return err
+	}
+
+	if len(app.Data) < len(dataBody) || app.Raw != nil {
+		app.Data = make([]byte, len(dataBody))
+	}
+	app.Data = app.Data[:len(dataBody)]
+	copy(app.Data, dataBody)
+	app.Raw = nil
+
+	return app.decodePayload()
+}
+
+// UnmarshalNoCopy decodes the Application packet from binary like
+// Unmarshal, but aliases Data directly into rawPacket instead of copying
+// it, avoiding an allocation per packet. rawPacket must not be modified
+// or reused until the caller is done with Data.
+func (app *Application) UnmarshalNoCopy(rawPacket []byte) error {
+	dataBody, err := app.unmarshalHeader(rawPacket)
+	if err != nil {
+		return err
+	}
+
+	app.Raw = rawPacket
+	app.Data = dataBody
+
+	return app.decodePayload()
+}
+
+// unmarshalHeader parses the header, SSRC and Name shared by Unmarshal
+// and UnmarshalNoCopy, returning the (still padded) application-dependent
+// data that follows.
+func (app *Application) unmarshalHeader(rawPacket []byte) ([]byte, error) {
 	/*
 	 *  0                   1                   2                   3
 	 *  0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
@@ -81,20 +195,20 @@ func (app *Application) Unmarshal(rawPacket []byte) error {
 
 	var header Header
 	if err := header.Unmarshal(rawPacket); err != nil {
-		return err
+		return nil, err
 	}
 
 	if header.Type != TypeApplicationDefined {
-		return errWrongType
+		return nil, errWrongType
 	}
 
 	if len(rawPacket) < headerLength || getPadding(len(rawPacket)) != 0 {
-		return errPacketTooShort
+		return nil, errPacketTooShort
 	}
 
 	packetBody := rawPacket[headerLength:]
 	if len(packetBody) < appDataOffset {
-		return errPacketTooShort
+		return nil, errPacketTooShort
 	}
 
 	app.SubType = header.Count
@@ -103,28 +217,72 @@ func (app *Application) Unmarshal(rawPacket []byte) error {
 
 	dataBody := packetBody[appDataOffset:]
 	if header.Padding {
-		len := len(dataBody) - int(dataBody[len(dataBody)-1])
-		dataBody = dataBody[:len]
+		if len(dataBody) == 0 || int(dataBody[len(dataBody)-1]) > len(dataBody) {
+			return nil, errPacketTooShort
+		}
+		trimmed := len(dataBody) - int(dataBody[len(dataBody)-1])
+		dataBody = dataBody[:trimmed]
 	}
-	if len(app.Data) < len(dataBody) {
-		app.Data = make([]byte, len(dataBody))
+
+	return dataBody, nil
+}
+
+// decodePayload looks up a codec for Name/SubType and, if one is
+// registered, decodes Data into Payload.
+func (app *Application) decodePayload() error {
+	app.Payload = nil
+
+	codec := applicationCodecFor(app.Name, app.SubType)
+	if codec == nil {
+		return nil
 	}
-	copy(app.Data, dataBody)
+
+	payload, err := codec.Unmarshal(app.Data)
+	if err != nil {
+		return fmt.Errorf("rtcp: application codec for %q: %w", app.Name[:], err)
+	}
+	if err := codec.Validate(payload); err != nil {
+		return fmt.Errorf("rtcp: application codec for %q: %w", app.Name[:], err)
+	}
+	app.Payload = payload
 
 	return nil
 }
 
-// Header returns the Header associated with this packet.
+// Header returns the Header associated with this packet. If Payload is
+// set, it is resolved into Data first (see the Payload field doc) so the
+// reported Length matches what Marshal/MarshalTo will actually write;
+// any codec error is ignored here and surfaces from Marshal/MarshalTo
+// instead, since Header has no error return.
 func (app *Application) Header() Header {
+	_ = app.encodePayload()
+	return app.headerFromData()
+}
+
+// headerFromData builds the Header from the current Data, without
+// attempting to resolve a pending Payload.
+func (app *Application) headerFromData() Header {
 	return Header{
 		Padding: getPadding(app.packetLen()) != 0,
 		Count:   app.SubType,
 		Type:    TypeApplicationDefined,
-		Length:  uint16((app.MarshalSize() / 4) - 1),
+		Length:  uint16((app.sizeFromData() / 4) - 1),
 	}
 }
 
+// MarshalSize returns the number of bytes Marshal/MarshalTo will write.
+// If Payload is set, it is resolved into Data first (see the Payload
+// field doc) so a buffer sized from this call is big enough for the
+// MarshalTo() that follows; any codec error is ignored here and surfaces
+// from Marshal/MarshalTo instead, since MarshalSize has no error return.
 func (app *Application) MarshalSize() int {
+	_ = app.encodePayload()
+	return app.sizeFromData()
+}
+
+// sizeFromData computes MarshalSize from the current Data, without
+// attempting to resolve a pending Payload.
+func (app *Application) sizeFromData() int {
 	l := app.packetLen()
 	return l + getPadding(l)
 }
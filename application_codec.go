@@ -0,0 +1,179 @@
+package rtcp
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	errWrongApplicationPayloadType  = errors.New("rtcp: payload does not match the type expected by this codec")
+	errTooManyREMBSSRCs             = errors.New("rtcp: too many SSRCs for a REMB payload")
+	errNoApplicationCodecForPayload = errors.New("rtcp: Application.Payload is set but no codec is registered for this Name/SubType")
+	errBufferTooSmall               = errors.New("rtcp: buffer too small for MarshalTo")
+)
+
+// ApplicationCodec decodes and encodes the application-dependent Data
+// carried by an Application packet into a concrete Go value, keyed by the
+// packet's Name (and optionally SubType). This lets callers work with typed
+// payloads instead of raw bytes, analogous to how pion/rtp exposes typed
+// RTP header extensions.
+type ApplicationCodec interface {
+	// Unmarshal decodes data (Application.Data with padding already
+	// stripped) into a payload value.
+	Unmarshal(data []byte) (payload any, err error)
+	// Marshal encodes payload, previously produced by Unmarshal or built by
+	// hand, back into raw APP data.
+	Marshal(payload any) (data []byte, err error)
+	// Validate reports whether payload is a value this codec can marshal.
+	// It is consulted after Unmarshal so malformed APP payloads surface a
+	// specific error instead of silently falling back to raw bytes.
+	Validate(payload any) error
+}
+
+type applicationCodecKey struct {
+	name    [4]byte
+	subType uint8
+}
+
+var (
+	applicationCodecsMu sync.RWMutex
+	// applicationCodecs holds codecs registered for a specific Name+SubType.
+	applicationCodecs = map[applicationCodecKey]ApplicationCodec{}
+	// applicationCodecsByName holds codecs registered for a Name regardless
+	// of SubType; consulted when no exact SubType match is found.
+	applicationCodecsByName = map[[4]byte]ApplicationCodec{}
+)
+
+// RegisterApplicationCodec installs codec as the decoder/encoder for APP
+// packets whose Name and SubType both match. Registering again for the
+// same name/subtype replaces the previous codec.
+func RegisterApplicationCodec(name [4]byte, subtype uint8, codec ApplicationCodec) {
+	applicationCodecsMu.Lock()
+	defer applicationCodecsMu.Unlock()
+	applicationCodecs[applicationCodecKey{name: name, subType: subtype}] = codec
+}
+
+// RegisterApplicationCodecForName installs codec as the decoder/encoder for
+// any APP packet whose Name matches, irrespective of SubType. A codec
+// registered via RegisterApplicationCodec for the same Name and a specific
+// SubType takes precedence over this one. Registering again for the same
+// name replaces the previous codec.
+func RegisterApplicationCodecForName(name [4]byte, codec ApplicationCodec) {
+	applicationCodecsMu.Lock()
+	defer applicationCodecsMu.Unlock()
+	applicationCodecsByName[name] = codec
+}
+
+// applicationCodecFor looks up the codec registered for name/subtype,
+// preferring an exact SubType match over a Name-only registration.
+func applicationCodecFor(name [4]byte, subtype uint8) ApplicationCodec {
+	applicationCodecsMu.RLock()
+	defer applicationCodecsMu.RUnlock()
+
+	if codec, ok := applicationCodecs[applicationCodecKey{name: name, subType: subtype}]; ok {
+		return codec
+	}
+	if codec, ok := applicationCodecsByName[name]; ok {
+		return codec
+	}
+	return nil
+}
+
+func init() {
+	RegisterApplicationCodecForName(rembApplicationName, rembApplicationCodec{})
+}
+
+// Only REMB ships as a built-in codec today. A second, vendor-specific
+// example (e.g. an ETSI or Cisco APP encoding) was considered, but no
+// documented wire format for one could be verified, and shipping a made
+// up encoding under a real vendor's name would be worse than shipping
+// none. RegisterApplicationCodec/RegisterApplicationCodecForName cover
+// this case for callers who have one.
+
+// REMBApplicationPayload is the typed payload decoded from a Google
+// "REMB" (Receiver Estimated Maximum Bitrate) APP packet: a num-SSRCs
+// byte, a 6-bit exponent / 18-bit mantissa bitrate, followed by the SSRC
+// list the estimate applies to.
+type REMBApplicationPayload struct {
+	// Bitrate is the estimated maximum receive bitrate in bits per second,
+	// equal to Mantissa << Exponent.
+	Bitrate uint64
+	// SSRCs the estimate applies to.
+	SSRCs []uint32
+}
+
+var rembApplicationName = [4]byte{'R', 'E', 'M', 'B'}
+
+type rembApplicationCodec struct{}
+
+const rembHeaderLength = 4 // num-SSRCs byte + 3 bytes of exponent/mantissa
+
+func (rembApplicationCodec) Unmarshal(data []byte) (any, error) {
+	if len(data) < rembHeaderLength {
+		return nil, errPacketTooShort
+	}
+
+	numSSRC := int(data[0])
+	exponent := data[1] >> 2
+	mantissa := uint64(data[1]&0x03)<<16 | uint64(data[2])<<8 | uint64(data[3])
+
+	if len(data) < rembHeaderLength+numSSRC*4 {
+		return nil, errPacketTooShort
+	}
+
+	ssrcs := make([]uint32, numSSRC)
+	for i := range ssrcs {
+		ssrcs[i] = binary.BigEndian.Uint32(data[rembHeaderLength+i*4:])
+	}
+
+	return REMBApplicationPayload{
+		Bitrate: mantissa << exponent,
+		SSRCs:   ssrcs,
+	}, nil
+}
+
+func (rembApplicationCodec) Marshal(payload any) ([]byte, error) {
+	remb, ok := payload.(REMBApplicationPayload)
+	if !ok {
+		return nil, errWrongApplicationPayloadType
+	}
+
+	mantissa, exponent := rembBitrateToMantissaExponent(remb.Bitrate)
+
+	data := make([]byte, rembHeaderLength+len(remb.SSRCs)*4)
+	data[0] = uint8(len(remb.SSRCs))
+	data[1] = exponent<<2 | uint8(mantissa>>16)
+	data[2] = uint8(mantissa >> 8)
+	data[3] = uint8(mantissa)
+
+	for i, ssrc := range remb.SSRCs {
+		binary.BigEndian.PutUint32(data[rembHeaderLength+i*4:], ssrc)
+	}
+
+	return data, nil
+}
+
+func (rembApplicationCodec) Validate(payload any) error {
+	remb, ok := payload.(REMBApplicationPayload)
+	if !ok {
+		return errWrongApplicationPayloadType
+	}
+	if len(remb.SSRCs) > 0xFF {
+		return fmt.Errorf("%w: %d SSRCs", errTooManyREMBSSRCs, len(remb.SSRCs))
+	}
+	return nil
+}
+
+// rembBitrateToMantissaExponent splits bitrate into the 18-bit mantissa and
+// 6-bit exponent pair REMB encodes it as, choosing the smallest exponent
+// that fits the mantissa in 18 bits.
+func rembBitrateToMantissaExponent(bitrate uint64) (mantissa uint64, exponent uint8) {
+	mantissa = bitrate
+	for mantissa > 0x3FFFF {
+		mantissa >>= 1
+		exponent++
+	}
+	return mantissa, exponent
+}
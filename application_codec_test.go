@@ -0,0 +1,117 @@
+package rtcp
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestREMBApplicationCodecRoundTrip(t *testing.T) {
+	payload := REMBApplicationPayload{
+		Bitrate: 1500000,
+		SSRCs:   []uint32{0x11223344, 0x55667788},
+	}
+
+	codec := rembApplicationCodec{}
+
+	data, err := codec.Marshal(payload)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	got, err := codec.Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	decoded, ok := got.(REMBApplicationPayload)
+	if !ok {
+		t.Fatalf("Unmarshal returned %T, want REMBApplicationPayload", got)
+	}
+
+	if decoded.Bitrate != payload.Bitrate {
+		t.Errorf("Bitrate = %d, want %d", decoded.Bitrate, payload.Bitrate)
+	}
+	if !reflect.DeepEqual(decoded.SSRCs, payload.SSRCs) {
+		t.Errorf("SSRCs = %v, want %v", decoded.SSRCs, payload.SSRCs)
+	}
+}
+
+func TestREMBApplicationCodecUnmarshalTooShort(t *testing.T) {
+	codec := rembApplicationCodec{}
+
+	if _, err := codec.Unmarshal([]byte{0x01, 0x02}); !errors.Is(err, errPacketTooShort) {
+		t.Fatalf("Unmarshal err = %v, want errPacketTooShort", err)
+	}
+
+	// num-SSRCs says 2 but only one SSRC worth of bytes follows.
+	short := []byte{0x02, 0x00, 0x00, 0x00, 0x11, 0x22, 0x33, 0x44}
+	if _, err := codec.Unmarshal(short); !errors.Is(err, errPacketTooShort) {
+		t.Fatalf("Unmarshal err = %v, want errPacketTooShort", err)
+	}
+}
+
+func TestREMBApplicationCodecValidate(t *testing.T) {
+	codec := rembApplicationCodec{}
+
+	if err := codec.Validate("not a payload"); err == nil {
+		t.Fatal("Validate accepted a non-REMBApplicationPayload value")
+	}
+
+	tooMany := REMBApplicationPayload{SSRCs: make([]uint32, 0x100)}
+	if err := codec.Validate(tooMany); !errors.Is(err, errTooManyREMBSSRCs) {
+		t.Fatalf("Validate err = %v, want errTooManyREMBSSRCs", err)
+	}
+
+	ok := REMBApplicationPayload{SSRCs: []uint32{1}}
+	if err := codec.Validate(ok); err != nil {
+		t.Fatalf("Validate returned unexpected error: %v", err)
+	}
+}
+
+func TestApplicationCodecRegistryPrecedence(t *testing.T) {
+	name := [4]byte{'T', 'S', 'T', '1'}
+
+	byName := stubApplicationCodec{tag: "by-name"}
+	bySubtype := stubApplicationCodec{tag: "by-subtype"}
+
+	RegisterApplicationCodecForName(name, byName)
+	RegisterApplicationCodec(name, 5, bySubtype)
+	t.Cleanup(func() {
+		delete(applicationCodecsByName, name)
+		delete(applicationCodecs, applicationCodecKey{name: name, subType: 5})
+	})
+
+	if got := applicationCodecFor(name, 5); got != bySubtype {
+		t.Errorf("applicationCodecFor(name, 5) = %v, want the subtype-specific codec", got)
+	}
+	if got := applicationCodecFor(name, 9); got != byName {
+		t.Errorf("applicationCodecFor(name, 9) = %v, want the name-only codec", got)
+	}
+}
+
+func TestRegisterApplicationCodecOverride(t *testing.T) {
+	name := [4]byte{'T', 'S', 'T', '2'}
+
+	first := stubApplicationCodec{tag: "first"}
+	second := stubApplicationCodec{tag: "second"}
+
+	RegisterApplicationCodecForName(name, first)
+	RegisterApplicationCodecForName(name, second)
+	t.Cleanup(func() { delete(applicationCodecsByName, name) })
+
+	if got := applicationCodecFor(name, 0); got != second {
+		t.Errorf("applicationCodecFor = %v, want the most recently registered codec", got)
+	}
+}
+
+type stubApplicationCodec struct {
+	tag string
+}
+
+func (stubApplicationCodec) Unmarshal(data []byte) (any, error) { return data, nil }
+func (stubApplicationCodec) Marshal(payload any) ([]byte, error) {
+	b, _ := payload.([]byte)
+	return b, nil
+}
+func (stubApplicationCodec) Validate(any) error { return nil }
@@ -0,0 +1,224 @@
+package rtcp
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func testApplication() Application {
+	return Application{
+		SubType: 5,
+		SSRC:    0xCAFEBABE,
+		Name:    [4]byte{'T', 'E', 'S', 'T'},
+		Data:    []byte{1, 2, 3, 4, 5},
+	}
+}
+
+func TestApplicationMarshalToMatchesMarshal(t *testing.T) {
+	app := testApplication()
+
+	want, err := app.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	buf := make([]byte, app.MarshalSize())
+	n, err := app.MarshalTo(buf)
+	if err != nil {
+		t.Fatalf("MarshalTo: %v", err)
+	}
+
+	if n != len(want) {
+		t.Fatalf("MarshalTo wrote %d bytes, want %d", n, len(want))
+	}
+	if !bytes.Equal(buf[:n], want) {
+		t.Fatalf("MarshalTo = %x, want %x", buf[:n], want)
+	}
+}
+
+func TestApplicationMarshalToFromPayloadOnly(t *testing.T) {
+	app := Application{
+		Name: rembApplicationName,
+		Payload: REMBApplicationPayload{
+			Bitrate: 1000,
+			SSRCs:   []uint32{0x1},
+		},
+	}
+
+	size := app.MarshalSize()
+	buf := make([]byte, size)
+	n, err := app.MarshalTo(buf)
+	if err != nil {
+		t.Fatalf("MarshalTo: %v", err)
+	}
+	if n != size {
+		t.Fatalf("MarshalTo wrote %d bytes, want MarshalSize() = %d", n, size)
+	}
+
+	var decoded Application
+	if err := decoded.Unmarshal(buf[:n]); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	payload, ok := decoded.Payload.(REMBApplicationPayload)
+	if !ok {
+		t.Fatalf("decoded Payload = %T, want REMBApplicationPayload", decoded.Payload)
+	}
+	if payload.Bitrate != 1000 || len(payload.SSRCs) != 1 || payload.SSRCs[0] != 0x1 {
+		t.Fatalf("decoded Payload = %+v, want Bitrate=1000 SSRCs=[1]", payload)
+	}
+}
+
+func TestApplicationMarshalToBufferTooSmall(t *testing.T) {
+	app := testApplication()
+
+	buf := make([]byte, app.MarshalSize()-1)
+	if _, err := app.MarshalTo(buf); !errors.Is(err, errBufferTooSmall) {
+		t.Fatalf("MarshalTo err = %v, want errBufferTooSmall", err)
+	}
+}
+
+func TestApplicationUnmarshalCopiesData(t *testing.T) {
+	app := testApplication()
+	raw, err := app.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var decoded Application
+	if err := decoded.Unmarshal(raw); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if decoded.Raw != nil {
+		t.Fatalf("Unmarshal set Raw = %v, want nil", decoded.Raw)
+	}
+
+	original := append([]byte(nil), decoded.Data...)
+	for i := range raw {
+		raw[i] = 0xFF
+	}
+
+	if !bytes.Equal(decoded.Data, original) {
+		t.Fatalf("Data changed after mutating raw input: Unmarshal should have copied it")
+	}
+}
+
+func TestApplicationUnmarshalNoCopyAliasesData(t *testing.T) {
+	app := testApplication()
+	raw, err := app.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var decoded Application
+	if err := decoded.UnmarshalNoCopy(raw); err != nil {
+		t.Fatalf("UnmarshalNoCopy: %v", err)
+	}
+	if decoded.Raw == nil {
+		t.Fatal("UnmarshalNoCopy left Raw nil")
+	}
+
+	for i := range raw {
+		raw[i] = 0xFF
+	}
+
+	for _, b := range decoded.Data {
+		if b != 0xFF {
+			t.Fatalf("Data did not alias raw input: got %x", decoded.Data)
+		}
+	}
+}
+
+// appPacketWithPaddingButNoData builds a 12-byte APP packet (header +
+// SSRC + Name, zero bytes of application data) with the header's padding
+// bit set, the malformed shape that used to panic on dataBody[-1].
+func appPacketWithPaddingButNoData(t *testing.T) []byte {
+	t.Helper()
+
+	header := Header{
+		Padding: true,
+		Count:   0,
+		Type:    TypeApplicationDefined,
+		Length:  2, // (12 bytes / 4) - 1
+	}
+	hData, err := header.Marshal()
+	if err != nil {
+		t.Fatalf("Header.Marshal: %v", err)
+	}
+
+	raw := append([]byte{}, hData...)
+	raw = append(raw, make([]byte, ssrcLength+appNameLength)...) // SSRC + Name, no data
+	return raw
+}
+
+func TestApplicationUnmarshalPaddingNoDataDoesNotPanic(t *testing.T) {
+	raw := appPacketWithPaddingButNoData(t)
+
+	var app Application
+	if err := app.Unmarshal(raw); !errors.Is(err, errPacketTooShort) {
+		t.Fatalf("Unmarshal err = %v, want errPacketTooShort", err)
+	}
+}
+
+func TestApplicationUnmarshalNoCopyPaddingNoDataDoesNotPanic(t *testing.T) {
+	raw := appPacketWithPaddingButNoData(t)
+
+	var app Application
+	if err := app.UnmarshalNoCopy(raw); !errors.Is(err, errPacketTooShort) {
+		t.Fatalf("UnmarshalNoCopy err = %v, want errPacketTooShort", err)
+	}
+}
+
+func BenchmarkApplicationMarshal(b *testing.B) {
+	app := testApplication()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := app.Marshal(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkApplicationMarshalTo(b *testing.B) {
+	app := testApplication()
+	buf := make([]byte, app.MarshalSize())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := app.MarshalTo(buf); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkApplicationUnmarshal(b *testing.B) {
+	app := testApplication()
+	raw, err := app.Marshal()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var decoded Application
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := decoded.Unmarshal(raw); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkApplicationUnmarshalNoCopy(b *testing.B) {
+	app := testApplication()
+	raw, err := app.Marshal()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var decoded Application
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := decoded.UnmarshalNoCopy(raw); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
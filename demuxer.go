@@ -0,0 +1,292 @@
+package rtcp
+
+import (
+	"errors"
+	"sync"
+)
+
+// BackpressurePolicy controls what a Demuxer does when a registered
+// consumer's channel is full.
+type BackpressurePolicy int
+
+const (
+	// BackpressureBlock blocks the feeding goroutine until the slow
+	// consumer catches up. This guarantees delivery but lets one slow
+	// consumer stall the whole Demuxer.
+	BackpressureBlock BackpressurePolicy = iota
+	// BackpressureDropOldest discards the oldest buffered packet to make
+	// room for the new one, so a slow consumer can't stall other
+	// consumers or Feed.
+	BackpressureDropOldest
+)
+
+var errDemuxerClosed = errors.New("rtcp: demuxer is closed")
+
+const defaultDemuxerBufferSize = 32
+
+// DemuxerOption configures a Demuxer constructed by NewDemuxer.
+type DemuxerOption func(*Demuxer)
+
+// WithDemuxerBufferSize sets the buffer size of channels returned by
+// RegisterSSRC and RegisterApp. The default is 32.
+func WithDemuxerBufferSize(n int) DemuxerOption {
+	return func(d *Demuxer) { d.bufferSize = n }
+}
+
+// WithDemuxerBackpressurePolicy sets what happens when a consumer's
+// channel is full. The default is BackpressureBlock.
+func WithDemuxerBackpressurePolicy(p BackpressurePolicy) DemuxerOption {
+	return func(d *Demuxer) { d.policy = p }
+}
+
+// Demuxer accepts inbound compound RTCP packets and dispatches them to
+// per-SSRC and per-APP-name handler channels, so callers don't need to
+// write their own dispatch loop on top of Unmarshal. A single goroutine
+// owns the registration maps, so registering, unregistering and feeding
+// packets never contend on a lock.
+type Demuxer struct {
+	bufferSize int
+	policy     BackpressurePolicy
+
+	registerSSRC   chan ssrcRegistration
+	unregisterSSRC chan uint32
+	registerApp    chan appRegistration
+	unregisterApp  chan [4]byte
+	feed           chan demuxerFeed
+	closed         chan struct{}
+	closeOnce      sync.Once
+}
+
+type ssrcRegistration struct {
+	ssrc uint32
+	ch   chan chan Packet
+}
+
+type appRegistration struct {
+	name [4]byte
+	ch   chan chan *Application
+}
+
+type demuxerFeed struct {
+	raw    []byte
+	result chan error
+}
+
+// NewDemuxer creates a Demuxer and starts the goroutine that owns it.
+// Close must be called to release it.
+func NewDemuxer(opts ...DemuxerOption) *Demuxer {
+	d := &Demuxer{
+		bufferSize:     defaultDemuxerBufferSize,
+		policy:         BackpressureBlock,
+		registerSSRC:   make(chan ssrcRegistration),
+		unregisterSSRC: make(chan uint32),
+		registerApp:    make(chan appRegistration),
+		unregisterApp:  make(chan [4]byte),
+		feed:           make(chan demuxerFeed),
+		closed:         make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	go d.run()
+
+	return d
+}
+
+// RegisterSSRC returns a channel that receives every Packet in a fed
+// compound packet whose DestinationSSRC includes ssrc. Registering the
+// same ssrc again closes the previous channel and replaces it. The
+// returned channel is closed once the Demuxer is closed or UnregisterSSRC
+// is called; it is already closed if the Demuxer was closed beforehand.
+func (d *Demuxer) RegisterSSRC(ssrc uint32) <-chan Packet {
+	reply := make(chan chan Packet, 1)
+	select {
+	case <-d.closed:
+		closedCh := make(chan Packet)
+		close(closedCh)
+		return closedCh
+	default:
+	}
+	select {
+	case d.registerSSRC <- ssrcRegistration{ssrc: ssrc, ch: reply}:
+		return <-reply
+	case <-d.closed:
+		closedCh := make(chan Packet)
+		close(closedCh)
+		return closedCh
+	}
+}
+
+// UnregisterSSRC stops dispatching to the channel returned by a prior
+// RegisterSSRC(ssrc) call and closes it.
+func (d *Demuxer) UnregisterSSRC(ssrc uint32) {
+	select {
+	case d.unregisterSSRC <- ssrc:
+	case <-d.closed:
+	}
+}
+
+// RegisterApp returns a channel that receives every Application packet in
+// a fed compound packet whose Name matches name. Registering the same
+// name again closes the previous channel and replaces it. The returned
+// channel is closed once the Demuxer is closed or UnregisterApp is
+// called; it is already closed if the Demuxer was closed beforehand.
+func (d *Demuxer) RegisterApp(name [4]byte) <-chan *Application {
+	reply := make(chan chan *Application, 1)
+	select {
+	case <-d.closed:
+		closedCh := make(chan *Application)
+		close(closedCh)
+		return closedCh
+	default:
+	}
+	select {
+	case d.registerApp <- appRegistration{name: name, ch: reply}:
+		return <-reply
+	case <-d.closed:
+		closedCh := make(chan *Application)
+		close(closedCh)
+		return closedCh
+	}
+}
+
+// UnregisterApp stops dispatching to the channel returned by a prior
+// RegisterApp(name) call and closes it.
+func (d *Demuxer) UnregisterApp(name [4]byte) {
+	select {
+	case d.unregisterApp <- name:
+	case <-d.closed:
+	}
+}
+
+// Feed parses raw as a compound RTCP packet and dispatches its packets to
+// any matching registered channels. It returns an error if raw fails to
+// parse or the Demuxer is closed.
+func (d *Demuxer) Feed(raw []byte) error {
+	result := make(chan error, 1)
+	select {
+	case <-d.closed:
+		return errDemuxerClosed
+	default:
+	}
+	select {
+	case d.feed <- demuxerFeed{raw: raw, result: result}:
+	case <-d.closed:
+		return errDemuxerClosed
+	}
+	return <-result
+}
+
+// Close stops the Demuxer's goroutine and closes every registered
+// channel. Feed, RegisterSSRC and RegisterApp are no-ops/closed-channel
+// after Close returns. Close may be called more than once; only the
+// first call has an effect.
+func (d *Demuxer) Close() {
+	d.closeOnce.Do(func() { close(d.closed) })
+}
+
+func (d *Demuxer) run() {
+	bySSRC := map[uint32]chan Packet{}
+	byApp := map[[4]byte]chan *Application{}
+
+	defer func() {
+		for _, ch := range bySSRC {
+			close(ch)
+		}
+		for _, ch := range byApp {
+			close(ch)
+		}
+	}()
+
+	for {
+		select {
+		case reg := <-d.registerSSRC:
+			if old, ok := bySSRC[reg.ssrc]; ok {
+				close(old)
+			}
+			ch := make(chan Packet, d.bufferSize)
+			bySSRC[reg.ssrc] = ch
+			reg.ch <- ch
+
+		case ssrc := <-d.unregisterSSRC:
+			if ch, ok := bySSRC[ssrc]; ok {
+				close(ch)
+				delete(bySSRC, ssrc)
+			}
+
+		case reg := <-d.registerApp:
+			if old, ok := byApp[reg.name]; ok {
+				close(old)
+			}
+			ch := make(chan *Application, d.bufferSize)
+			byApp[reg.name] = ch
+			reg.ch <- ch
+
+		case name := <-d.unregisterApp:
+			if ch, ok := byApp[name]; ok {
+				close(ch)
+				delete(byApp, name)
+			}
+
+		case f := <-d.feed:
+			f.result <- d.dispatch(f.raw, bySSRC, byApp)
+
+		case <-d.closed:
+			return
+		}
+	}
+}
+
+func (d *Demuxer) dispatch(raw []byte, bySSRC map[uint32]chan Packet, byApp map[[4]byte]chan *Application) error {
+	packets, err := Unmarshal(raw)
+	if err != nil {
+		return err
+	}
+
+	for _, pkt := range packets {
+		for _, ssrc := range pkt.DestinationSSRC() {
+			if ch, ok := bySSRC[ssrc]; ok {
+				d.send(ch, pkt)
+			}
+		}
+
+		if app, ok := pkt.(*Application); ok {
+			if ch, ok := byApp[app.Name]; ok {
+				d.sendApp(ch, app)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (d *Demuxer) send(ch chan Packet, pkt Packet) {
+	if d.policy == BackpressureDropOldest {
+		select {
+		case ch <- pkt:
+			return
+		default:
+		}
+		select {
+		case <-ch:
+		default:
+		}
+	}
+	ch <- pkt
+}
+
+func (d *Demuxer) sendApp(ch chan *Application, app *Application) {
+	if d.policy == BackpressureDropOldest {
+		select {
+		case ch <- app:
+			return
+		default:
+		}
+		select {
+		case <-ch:
+		default:
+		}
+	}
+	ch <- app
+}
@@ -0,0 +1,130 @@
+package rtcp
+
+import (
+	"testing"
+	"time"
+)
+
+func marshalApplication(t *testing.T, app Application) []byte {
+	t.Helper()
+	raw, err := app.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	return raw
+}
+
+func TestDemuxerCloseIsIdempotent(t *testing.T) {
+	d := NewDemuxer()
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("Close panicked: %v", r)
+		}
+	}()
+
+	d.Close()
+	d.Close()
+}
+
+func TestDemuxerDispatchBySSRC(t *testing.T) {
+	d := NewDemuxer()
+	defer d.Close()
+
+	ch := d.RegisterSSRC(0x11223344)
+
+	app := Application{SSRC: 0x11223344, Name: [4]byte{'A', 'B', 'C', 'D'}, Data: []byte{9}}
+	if err := d.Feed(marshalApplication(t, app)); err != nil {
+		t.Fatalf("Feed: %v", err)
+	}
+
+	select {
+	case pkt := <-ch:
+		got, ok := pkt.(*Application)
+		if !ok {
+			t.Fatalf("dispatched %T, want *Application", pkt)
+		}
+		if got.SSRC != app.SSRC {
+			t.Errorf("SSRC = %x, want %x", got.SSRC, app.SSRC)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for dispatched packet")
+	}
+}
+
+func TestDemuxerDispatchByAppName(t *testing.T) {
+	d := NewDemuxer()
+	defer d.Close()
+
+	name := [4]byte{'W', 'X', 'Y', 'Z'}
+	ch := d.RegisterApp(name)
+
+	app := Application{SSRC: 1, Name: name, Data: []byte{7, 7}}
+	if err := d.Feed(marshalApplication(t, app)); err != nil {
+		t.Fatalf("Feed: %v", err)
+	}
+
+	select {
+	case got := <-ch:
+		if got.Name != name {
+			t.Errorf("Name = %v, want %v", got.Name, name)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for dispatched packet")
+	}
+}
+
+func TestDemuxerUnregisterSSRCClosesChannel(t *testing.T) {
+	d := NewDemuxer()
+	defer d.Close()
+
+	ch := d.RegisterSSRC(42)
+	d.UnregisterSSRC(42)
+
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Fatal("channel delivered a value after UnregisterSSRC")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for channel to close")
+	}
+}
+
+func TestDemuxerBackpressureDropOldest(t *testing.T) {
+	d := NewDemuxer(WithDemuxerBufferSize(1), WithDemuxerBackpressurePolicy(BackpressureDropOldest))
+	defer d.Close()
+
+	ch := d.RegisterSSRC(99)
+
+	oldest := Application{SSRC: 99, Name: [4]byte{'O', 'L', 'D', '1'}, Data: []byte{1}}
+	newest := Application{SSRC: 99, Name: [4]byte{'N', 'E', 'W', '1'}, Data: []byte{2}}
+
+	if err := d.Feed(marshalApplication(t, oldest)); err != nil {
+		t.Fatalf("Feed(oldest): %v", err)
+	}
+	if err := d.Feed(marshalApplication(t, newest)); err != nil {
+		t.Fatalf("Feed(newest): %v", err)
+	}
+
+	select {
+	case pkt := <-ch:
+		got, ok := pkt.(*Application)
+		if !ok {
+			t.Fatalf("dispatched %T, want *Application", pkt)
+		}
+		if got.Name != newest.Name {
+			t.Errorf("Name = %v, want %v (oldest should have been dropped)", got.Name, newest.Name)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for dispatched packet")
+	}
+
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Fatal("received a second packet; expected the oldest to have been dropped")
+		}
+	default:
+	}
+}